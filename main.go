@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/cpi"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/csvimport"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/mt940"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/pkoxml"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/report"
+)
+
+func main() {
+	input := flag.String("input", "operations.xml", "path to the bank statement export to import")
+	format := flag.String("format", "pkoxml", "import format: pkoxml, mt940 (also handles CAMT.053), or csv")
+	csvMappingPath := flag.String("csv-mapping", "", "path to a JSON file holding a csvimport.ColumnMapping; required with -format=csv")
+	cpiPath := flag.String("cpi", "", "optional path to a CPI table (CSV or JSON) enabling the real PLN view")
+	cpiBase := flag.String("cpi-base", "", "base month (YYYY-MM) the real PLN view deflates to; required with -cpi")
+	flag.Parse()
+
+	fileData, err := os.ReadFile(*input)
+	if err != nil {
+		panic(err)
+	}
+
+	imp, err := importer(*format, *csvMappingPath)
+	if err != nil {
+		panic(err)
+	}
+
+	ops, err := imp.Import(fileData)
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := reportOptions(*cpiPath, *cpiBase)
+	if err != nil {
+		panic(err)
+	}
+
+	report.GenerateWithOptions(ops, opts)
+}
+
+// importer resolves the loan.Importer named by format. For "csv", the
+// column mapping is loaded as JSON from csvMappingPath since CSV exports
+// have no standard layout.
+func importer(format, csvMappingPath string) (loan.Importer, error) {
+	switch format {
+	case "", "pkoxml":
+		return pkoxml.New(), nil
+	case "mt940":
+		return mt940.New(), nil
+	case "csv":
+		if csvMappingPath == "" {
+			return nil, fmt.Errorf("-csv-mapping is required with -format=csv")
+		}
+		data, err := os.ReadFile(csvMappingPath)
+		if err != nil {
+			return nil, err
+		}
+		var mapping csvimport.ColumnMapping
+		if err := json.Unmarshal(data, &mapping); err != nil {
+			return nil, err
+		}
+		return csvimport.New(mapping), nil
+	default:
+		return nil, fmt.Errorf("unknown -format %q (want pkoxml, mt940, or csv)", format)
+	}
+}
+
+func reportOptions(cpiPath, cpiBase string) (report.Options, error) {
+	if cpiPath == "" {
+		return report.Options{}, nil
+	}
+	if cpiBase == "" {
+		return report.Options{}, fmt.Errorf("-cpi-base is required when -cpi is set")
+	}
+
+	data, err := os.ReadFile(cpiPath)
+	if err != nil {
+		return report.Options{}, err
+	}
+
+	var table cpi.Table
+	if strings.HasSuffix(cpiPath, ".json") {
+		table, err = cpi.LoadJSON(data)
+	} else {
+		table, err = cpi.LoadCSV(data)
+	}
+	if err != nil {
+		return report.Options{}, err
+	}
+
+	return report.Options{CPI: table, CPIBaseMonth: cpiBase}, nil
+}
@@ -0,0 +1,235 @@
+// Package amortize reconstructs a loan's amortization schedule from its
+// observed repayment history and projects the remaining payments to
+// payoff, since the source bank exports only cover the period the
+// statement was downloaded for.
+package amortize
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+// maxProjectedMonths bounds the projection loop in case a loan's observed
+// payment amortizes the remaining balance only very slowly. A payment that
+// doesn't even cover the accruing interest is rejected by project before
+// this bound is reached.
+const maxProjectedMonths = 1200 // 100 years
+
+// trendMonths is how many of the most recent actual months are averaged to
+// estimate the fixed monthly payment used for projecting the remainder of
+// the schedule.
+const trendMonths = 6
+
+// MonthlyPoint is one month of a loan's amortization schedule.
+type MonthlyPoint struct {
+	Month     string // "2006-01"
+	Kapital   decimal.Decimal
+	Odsetki   decimal.Decimal
+	Balance   decimal.Decimal
+	Projected bool
+}
+
+// Schedule is a loan's reconstructed amortization schedule: the actual
+// history inferred from observed operations, followed by a projection of
+// the remaining payments to payoff.
+type Schedule struct {
+	Principal   decimal.Decimal
+	MonthlyRate decimal.Decimal
+	NominalRate decimal.Decimal // annual, MonthlyRate * 12
+	Actual      []MonthlyPoint
+	Projected   []MonthlyPoint
+	PayoffDate  string // "2006-01" of the last projected month, or of the last actual month if none is projected
+}
+
+// Summary is the headline numbers for a loan's report card.
+type Summary struct {
+	TotalPaid        decimal.Decimal
+	TotalInterest    decimal.Decimal
+	RemainingBalance decimal.Decimal
+	EffectiveRate    decimal.Decimal // annual, compounded from MonthlyRate
+	PayoffDate       string
+}
+
+// Build reconstructs a Schedule from a single loan ID's operations and
+// projects its remaining payments to payoff.
+func Build(ops []loan.Operation) (*Schedule, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("amortize: no operations to build a schedule from")
+	}
+
+	actual, err := monthlyPoints(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := actual[0].Balance.Add(actual[0].Kapital)
+	monthlyRate := averageMonthlyRate(actual, principal)
+
+	s := &Schedule{
+		Principal:   principal,
+		MonthlyRate: monthlyRate,
+		NominalRate: monthlyRate.Mul(decimal.NewFromInt(12)),
+		Actual:      actual,
+	}
+
+	last := actual[len(actual)-1]
+	if last.Balance.Sign() > 0 {
+		s.Projected, err = project(last, monthlyRate, averagePayment(actual))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.PayoffDate = last.Month
+	if n := len(s.Projected); n > 0 {
+		s.PayoffDate = s.Projected[n-1].Month
+	}
+
+	return s, nil
+}
+
+// Summarize computes a Schedule's report-card numbers.
+func Summarize(s *Schedule) Summary {
+	totalPaid := decimal.Zero
+	totalInterest := decimal.Zero
+	for _, p := range s.Actual {
+		totalPaid = totalPaid.Add(p.Kapital).Add(p.Odsetki)
+		totalInterest = totalInterest.Add(p.Odsetki)
+	}
+
+	onePlusMonthly := decimal.NewFromInt(1).Add(s.MonthlyRate)
+	compounded, _ := onePlusMonthly.PowInt32(12)
+	effectiveRate := compounded.Sub(decimal.NewFromInt(1))
+
+	return Summary{
+		TotalPaid:        totalPaid,
+		TotalInterest:    totalInterest,
+		RemainingBalance: s.Actual[len(s.Actual)-1].Balance,
+		EffectiveRate:    effectiveRate,
+		PayoffDate:       s.PayoffDate,
+	}
+}
+
+// monthlyPoints buckets ops by calendar month, summing Kapital/Odsetki and
+// taking the last observed EndingBalance as that month's closing balance.
+func monthlyPoints(ops []loan.Operation) ([]MonthlyPoint, error) {
+	sort.Slice(ops, func(i, j int) bool {
+		ti, _ := time.Parse("2006-01-02", ops[i].OrderDate)
+		tj, _ := time.Parse("2006-01-02", ops[j].OrderDate)
+		return ti.Before(tj)
+	})
+
+	byMonth := make(map[string]*MonthlyPoint)
+	var order []string
+
+	for _, op := range ops {
+		dt, err := time.Parse("2006-01-02", op.OrderDate)
+		if err != nil {
+			continue
+		}
+		mon := dt.Format("2006-01")
+
+		p, ok := byMonth[mon]
+		if !ok {
+			p = &MonthlyPoint{Month: mon}
+			byMonth[mon] = p
+			order = append(order, mon)
+		}
+		p.Kapital = p.Kapital.Add(loan.Amount{Value: op.Kapital}.Decimal())
+		p.Odsetki = p.Odsetki.Add(loan.Amount{Value: op.Odsetki}.Decimal())
+		p.Balance = op.EndingBalance.Decimal()
+	}
+
+	if len(order) == 0 {
+		return nil, fmt.Errorf("amortize: no operation had a parseable order date")
+	}
+
+	points := make([]MonthlyPoint, 0, len(order))
+	for _, mon := range order {
+		points = append(points, *byMonth[mon])
+	}
+	return points, nil
+}
+
+// averageMonthlyRate estimates the loan's monthly interest rate as the mean
+// of each month's Odsetki over the balance owed going into that month.
+func averageMonthlyRate(points []MonthlyPoint, principal decimal.Decimal) decimal.Decimal {
+	prevBalance := principal
+	sum := decimal.Zero
+	n := 0
+
+	for _, p := range points {
+		if prevBalance.Sign() > 0 {
+			sum = sum.Add(p.Odsetki.Div(prevBalance))
+			n++
+		}
+		prevBalance = p.Balance
+	}
+
+	if n == 0 {
+		return decimal.Zero
+	}
+	return sum.Div(decimal.NewFromInt(int64(n)))
+}
+
+// averagePayment estimates the loan's fixed monthly installment as the mean
+// total payment (Kapital+Odsetki) over the most recent trendMonths months,
+// since a variable-rate loan's installment can be recalculated over time.
+func averagePayment(points []MonthlyPoint) decimal.Decimal {
+	from := len(points) - trendMonths
+	if from < 0 {
+		from = 0
+	}
+
+	window := points[from:]
+	sum := decimal.Zero
+	for _, p := range window {
+		sum = sum.Add(p.Kapital).Add(p.Odsetki)
+	}
+	return sum.Div(decimal.NewFromInt(int64(len(window))))
+}
+
+// project extends the schedule forward from last month by last.Balance,
+// applying monthlyRate interest against a fixed monthlyPayment installment
+// until the balance is paid off.
+func project(last MonthlyPoint, monthlyRate, monthlyPayment decimal.Decimal) ([]MonthlyPoint, error) {
+	balance := last.Balance
+	month, err := time.Parse("2006-01", last.Month)
+	if err != nil {
+		return nil, fmt.Errorf("amortize: invalid month %q: %w", last.Month, err)
+	}
+
+	var points []MonthlyPoint
+	for i := 0; i < maxProjectedMonths && balance.Sign() > 0; i++ {
+		month = month.AddDate(0, 1, 0)
+		interest := balance.Mul(monthlyRate)
+		principal := monthlyPayment.Sub(interest)
+
+		if principal.Sign() <= 0 {
+			return nil, fmt.Errorf("amortize: projected installment %s does not cover accruing interest %s", monthlyPayment, interest)
+		}
+		if principal.GreaterThan(balance) {
+			principal = balance
+		}
+		balance = balance.Sub(principal)
+
+		points = append(points, MonthlyPoint{
+			Month:     month.Format("2006-01"),
+			Kapital:   principal,
+			Odsetki:   interest,
+			Balance:   balance,
+			Projected: true,
+		})
+	}
+
+	if balance.Sign() > 0 {
+		return nil, fmt.Errorf("amortize: loan does not amortize within %d months at the projected installment", maxProjectedMonths)
+	}
+
+	return points, nil
+}
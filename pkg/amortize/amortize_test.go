@@ -0,0 +1,58 @@
+package amortize
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+func TestBuildAndSummarize_SingleMonthPayoff(t *testing.T) {
+	ops := []loan.Operation{
+		{
+			OrderDate:     "2024-01-15",
+			Kapital:       "100,00",
+			Odsetki:       "10,00",
+			EndingBalance: loan.Amount{Value: "0,00"},
+		},
+	}
+
+	schedule, err := Build(ops)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if !schedule.Principal.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Principal = %s, want 100", schedule.Principal)
+	}
+	if !schedule.MonthlyRate.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("MonthlyRate = %s, want 0.1", schedule.MonthlyRate)
+	}
+	if len(schedule.Projected) != 0 {
+		t.Errorf("Projected = %d months, want 0 since the balance is already paid off", len(schedule.Projected))
+	}
+
+	summary := Summarize(schedule)
+	if !summary.TotalPaid.Equal(decimal.NewFromInt(110)) {
+		t.Errorf("TotalPaid = %s, want 110", summary.TotalPaid)
+	}
+	if !summary.TotalInterest.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("TotalInterest = %s, want 10", summary.TotalInterest)
+	}
+	if !summary.RemainingBalance.IsZero() {
+		t.Errorf("RemainingBalance = %s, want 0", summary.RemainingBalance)
+	}
+
+	wantEffective, _ := decimal.NewFromFloat(1.1).PowInt32(12)
+	wantEffective = wantEffective.Sub(decimal.NewFromInt(1))
+	if diff := summary.EffectiveRate.Sub(wantEffective).Abs(); diff.GreaterThan(decimal.NewFromFloat(0.0001)) {
+		t.Errorf("EffectiveRate = %s, want ~%s", summary.EffectiveRate, wantEffective)
+	}
+}
+
+func TestBuild_NoOperations(t *testing.T) {
+	if _, err := Build(nil); err == nil {
+		t.Fatal("Build(nil): want error, got nil")
+	}
+}
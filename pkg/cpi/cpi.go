@@ -0,0 +1,109 @@
+// Package cpi loads a monthly consumer price index table — such as GUS's
+// (Statistics Poland) monthly inflation index — used to deflate historical
+// loan payments to a chosen base month's PLN.
+package cpi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Table is a monthly CPI index, keyed by "2006-01".
+type Table map[string]decimal.Decimal
+
+// LoadCSV loads a CPI table from "month,index" rows, e.g. "2024-01,134.7".
+// A header row is tolerated and skipped if its index column doesn't parse
+// as a number.
+func LoadCSV(data []byte) (Table, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	table := make(Table)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			continue
+		}
+
+		month := strings.TrimSpace(record[0])
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			continue // header row or malformed line
+		}
+
+		table[month] = decimal.NewFromFloat(value)
+	}
+
+	return table, nil
+}
+
+// LoadJSON loads a CPI table from a JSON object mapping "2006-01" to its
+// index value, e.g. {"2024-01": 134.7}.
+func LoadJSON(data []byte) (Table, error) {
+	var raw map[string]float64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	table := make(Table, len(raw))
+	for month, value := range raw {
+		table[month] = decimal.NewFromFloat(value)
+	}
+	return table, nil
+}
+
+// ForwardFill fills every month in months that the table doesn't already
+// have a value for with the nearest earlier month's value, so a loan
+// history can be deflated even across months GUS hasn't published yet.
+func (t Table) ForwardFill(months []string) {
+	sorted := make([]string, len(months))
+	copy(sorted, months)
+	sort.Strings(sorted)
+
+	for _, mon := range sorted {
+		if _, ok := t[mon]; ok {
+			continue
+		}
+		var nearest string
+		for known := range t {
+			if known < mon && known > nearest {
+				nearest = known
+			}
+		}
+		if nearest != "" {
+			t[mon] = t[nearest]
+		}
+	}
+}
+
+// Deflate converts a nominal amount incurred in month into base month's
+// PLN: real = nominal * CPI[base] / CPI[month].
+func (t Table) Deflate(nominal decimal.Decimal, month, base string) (decimal.Decimal, error) {
+	monthIndex, ok := t[month]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("cpi: no index for month %q", month)
+	}
+	baseIndex, ok := t[base]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("cpi: no index for base month %q", base)
+	}
+	if monthIndex.IsZero() {
+		return decimal.Zero, fmt.Errorf("cpi: index for month %q is zero", month)
+	}
+
+	return nominal.Mul(baseIndex).Div(monthIndex), nil
+}
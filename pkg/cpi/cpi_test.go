@@ -0,0 +1,68 @@
+package cpi
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestLoadCSV(t *testing.T) {
+	data := []byte("month,index\n2024-01,134.7\n2024-02,135.2\n")
+
+	table, err := LoadCSV(data)
+	if err != nil {
+		t.Fatalf("LoadCSV: %v", err)
+	}
+	if len(table) != 2 {
+		t.Fatalf("got %d entries, want 2", len(table))
+	}
+	if !table["2024-01"].Equal(decimal.NewFromFloat(134.7)) {
+		t.Errorf("2024-01 = %s, want 134.7", table["2024-01"])
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	data := []byte(`{"2024-01": 134.7}`)
+
+	table, err := LoadJSON(data)
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+	if !table["2024-01"].Equal(decimal.NewFromFloat(134.7)) {
+		t.Errorf("2024-01 = %s, want 134.7", table["2024-01"])
+	}
+}
+
+func TestTable_ForwardFill(t *testing.T) {
+	table := Table{"2024-01": decimal.NewFromFloat(100)}
+	table.ForwardFill([]string{"2024-01", "2024-02", "2024-03"})
+
+	if !table["2024-02"].Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("2024-02 = %s, want forward-filled 100", table["2024-02"])
+	}
+	if !table["2024-03"].Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("2024-03 = %s, want forward-filled 100", table["2024-03"])
+	}
+}
+
+func TestTable_Deflate(t *testing.T) {
+	table := Table{
+		"2024-01": decimal.NewFromFloat(100),
+		"2024-06": decimal.NewFromFloat(110),
+	}
+
+	real, err := table.Deflate(decimal.NewFromFloat(110), "2024-06", "2024-01")
+	if err != nil {
+		t.Fatalf("Deflate: %v", err)
+	}
+	if !real.Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("Deflate = %s, want 100", real)
+	}
+}
+
+func TestTable_Deflate_MissingMonth(t *testing.T) {
+	table := Table{"2024-01": decimal.NewFromFloat(100)}
+	if _, err := table.Deflate(decimal.NewFromFloat(100), "2024-06", "2024-01"); err == nil {
+		t.Fatal("Deflate with an unknown month: want error, got nil")
+	}
+}
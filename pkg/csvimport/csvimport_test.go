@@ -0,0 +1,60 @@
+package csvimport
+
+import "testing"
+
+// TestImport_DescriptionFallback guards against the zero-value of
+// UseDirectColumns silently aliasing a real column index: a mapping that
+// only sets OrderDate/Description (the documented fallback use case) must
+// not read Kapital/Odsetki/ID off column 0.
+func TestImport_DescriptionFallback(t *testing.T) {
+	data := []byte(`2024-01-15,"KAPITAŁ: 1000,00 ODSETKI: 50,00 ODSETKI SKAPIT.: 0,00 777"` + "\n")
+
+	imp := New(ColumnMapping{OrderDate: 0, Description: 1})
+	ops, err := imp.Import(data)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("got %d operations, want 1", len(ops))
+	}
+
+	op := ops[0]
+	if op.ID != "777" {
+		t.Errorf("ID = %q, want %q", op.ID, "777")
+	}
+	if op.Kapital != "1000,00" {
+		t.Errorf("Kapital = %q, want %q", op.Kapital, "1000,00")
+	}
+	if op.Odsetki != "50,00" {
+		t.Errorf("Odsetki = %q, want %q", op.Odsetki, "50,00")
+	}
+}
+
+// TestImport_DirectColumns exercises UseDirectColumns, which skips
+// Description parsing entirely.
+func TestImport_DirectColumns(t *testing.T) {
+	data := []byte("2024-01-15,1000.00,50.00,777\n")
+
+	imp := New(ColumnMapping{
+		OrderDate:        0,
+		Kapital:          1,
+		Odsetki:          2,
+		ID:               3,
+		UseDirectColumns: true,
+	})
+	ops, err := imp.Import(data)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("got %d operations, want 1", len(ops))
+	}
+
+	op := ops[0]
+	if op.ID != "777" {
+		t.Errorf("ID = %q, want %q", op.ID, "777")
+	}
+	if op.Kapital != "1000.00" {
+		t.Errorf("Kapital = %q, want %q", op.Kapital, "1000.00")
+	}
+}
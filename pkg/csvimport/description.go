@@ -0,0 +1,31 @@
+package csvimport
+
+import (
+	"regexp"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+// loanDescriptionRe mirrors the regex pkoxml and mt940 use to pull the
+// Kapitał / Odsetki breakdown out of a PKO-style free-text description.
+var loanDescriptionRe = regexp.MustCompile(`KAPITAŁ: ([0-9,]+)\s+ODSETKI: ([0-9,]+)\s+ODSETKI SKAPIT\.: ([0-9,]+)(?:\s+ODSETKI KARNE: ([0-9,]+))?\s+(\d+)`)
+
+// fillFromDescription extracts the loan ID and Kapitał/Odsetki breakdown
+// from a free-text description, if present.
+func fillFromDescription(op *loan.Operation, description string) {
+	m := loanDescriptionRe.FindStringSubmatch(description)
+	if m == nil {
+		return
+	}
+
+	odsetkiKarne := "0,00"
+	if len(m) >= 5 && m[4] != "" {
+		odsetkiKarne = m[4]
+	}
+
+	op.Kapital = m[1]
+	op.Odsetki = m[2]
+	op.OdsetkiSkarpit = m[3]
+	op.OdsetkiKarne = odsetkiKarne
+	op.ID = m[5]
+}
@@ -0,0 +1,50 @@
+// Package loan holds the bank-agnostic representation of a loan repayment
+// event. Every importer (PKO XML, MT940/CAMT.053, CSV, ...) normalizes into
+// this shape so the report package never has to know where the data came
+// from.
+package loan
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Amount is a monetary value as printed on the source statement. Value
+// uses the comma decimal separator Polish bank exports print (e.g.
+// "1234,56").
+type Amount struct {
+	Currency string
+	Value    string
+}
+
+// Decimal parses Value as a fixed-precision decimal, treating an
+// unparseable value as zero the way the original float64 parsing did.
+func (a Amount) Decimal() decimal.Decimal {
+	d, err := decimal.NewFromString(strings.Replace(strings.TrimSpace(a.Value), ",", ".", 1))
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+// Operation is a single loan repayment event, normalized across bank
+// statement formats.
+type Operation struct {
+	OrderDate      string
+	ExecDate       string
+	Type           string
+	Amount         Amount
+	EndingBalance  Amount
+	Kapital        string
+	Odsetki        string
+	OdsetkiSkarpit string
+	OdsetkiKarne   string
+	ID             string
+}
+
+// Importer turns the raw bytes of a bank statement export into a flat,
+// normalized Operation stream.
+type Importer interface {
+	Import(data []byte) ([]Operation, error)
+}
@@ -0,0 +1,26 @@
+package loan
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAmount_Decimal(t *testing.T) {
+	cases := []struct {
+		value string
+		want  decimal.Decimal
+	}{
+		{"1234,56", decimal.NewFromFloat(1234.56)},
+		{" 100,00 ", decimal.NewFromFloat(100)},
+		{"", decimal.Zero},
+		{"not a number", decimal.Zero},
+	}
+
+	for _, c := range cases {
+		got := Amount{Value: c.value}.Decimal()
+		if !got.Equal(c.want) {
+			t.Errorf("Amount{Value: %q}.Decimal() = %s, want %s", c.value, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package mt940
+
+import (
+	"encoding/xml"
+	"regexp"
+)
+
+// loanDescriptionRe mirrors the regex pkoxml uses to pull the Kapitał /
+// Odsetki breakdown out of a PKO-style free-text description. MT940's :86:
+// and CAMT.053's AddtlNtryInf both carry that same text verbatim when the
+// export originates from PKO, so the same pattern applies here.
+var loanDescriptionRe = regexp.MustCompile(`KAPITAŁ: ([0-9,]+)\s+ODSETKI: ([0-9,]+)\s+ODSETKI SKAPIT\.: ([0-9,]+)(?:\s+ODSETKI KARNE: ([0-9,]+))?\s+(\d+)`)
+
+// ParsePKODescription is the default DescriptionParser. It matches PKO's
+// own convention of embedding the Kapitał/Odsetki/ID breakdown as free text
+// ("KAPITAŁ: ... ODSETKI: ... ODSETKI SKAPIT.: ..."); other banks' exports
+// won't match this, and need their own DescriptionParser passed to
+// NewWithParser.
+func ParsePKODescription(description string) (kapital, odsetki, odsetkiSkarpit, odsetkiKarne, id string, ok bool) {
+	m := loanDescriptionRe.FindStringSubmatch(description)
+	if m == nil {
+		return "", "", "", "", "", false
+	}
+
+	odsetkiKarne = "0,00"
+	if len(m) >= 5 && m[4] != "" {
+		odsetkiKarne = m[4]
+	}
+
+	return m[1], m[2], m[3], odsetkiKarne, m[5], true
+}
+
+// camt053Document is the small subset of the ISO 20022 camt.053.001 schema
+// this importer needs.
+type camt053Document struct {
+	XMLName       xml.Name `xml:"Document"`
+	BkToCstmrStmt struct {
+		Stmt []struct {
+			Ntry []struct {
+				Amt struct {
+					Ccy   string `xml:"Ccy,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"Amt"`
+				BookgDt struct {
+					Dt string `xml:"Dt"`
+				} `xml:"BookgDt"`
+				ValDt struct {
+					Dt string `xml:"Dt"`
+				} `xml:"ValDt"`
+				AddtlNtryInf string `xml:"AddtlNtryInf"`
+			} `xml:"Ntry"`
+		} `xml:"Stmt"`
+	} `xml:"BkToCstmrStmt"`
+}
+
+func unmarshalCAMT053(data []byte, doc *camt053Document) error {
+	return xml.Unmarshal(data, doc)
+}
@@ -0,0 +1,201 @@
+// Package mt940 imports SWIFT MT940 and ISO 20022 CAMT.053 bank statements
+// into normalized loan.Operation records, producing the same stream the
+// pkoxml importer does. The Kapitał/Odsetki/ID breakdown is extracted from
+// each entry's free-text field by a DescriptionParser; New uses the
+// default one matching PKO's own convention, NewWithParser takes a
+// different one for other banks' exports.
+package mt940
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+// DescriptionParser extracts the Kapitał/Odsetki breakdown and loan ID from
+// a statement's free-text field (MT940's :86:, CAMT.053's AddtlNtryInf). ok
+// is false when description doesn't match the expected convention.
+type DescriptionParser func(description string) (kapital, odsetki, odsetkiSkarpit, odsetkiKarne, id string, ok bool)
+
+// Importer reads MT940 or CAMT.053 statement exports. It auto-detects the
+// format: CAMT.053 is an XML document, MT940 is SWIFT tag/value text.
+type Importer struct {
+	parser DescriptionParser
+}
+
+// New returns an MT940/CAMT.053 Importer using ParsePKODescription, which
+// matches PKO's own "KAPITAŁ: ... ODSETKI: ..." convention.
+func New() *Importer {
+	return &Importer{parser: ParsePKODescription}
+}
+
+// NewWithParser returns an MT940/CAMT.053 Importer that extracts the
+// Kapitał/Odsetki/ID breakdown from the free-text field using parser,
+// for banks that encode that information differently than PKO does.
+func NewWithParser(parser DescriptionParser) *Importer {
+	return &Importer{parser: parser}
+}
+
+// Import parses data as CAMT.053 if it looks like XML, otherwise as MT940.
+func (i *Importer) Import(data []byte) ([]loan.Operation, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return i.importCAMT053(data)
+	}
+	return i.importMT940(data)
+}
+
+// statement61 is a parsed SWIFT field :61: statement line, one per
+// transaction.
+type statement61 struct {
+	valueDate string // YYMMDD
+	sign      string // "C" or "D"
+	amount    string
+}
+
+// importMT940 parses the :61:/:86: transaction pairs of an MT940 statement
+// into loan.Operations. Each :61: line carries the value date, sign and
+// amount; the following :86: line carries the free-text description the
+// Importer's parser extracts the Kapitał/Odsetki breakdown from.
+func (i *Importer) importMT940(data []byte) ([]loan.Operation, error) {
+	var ops []loan.Operation
+	var cur *statement61
+	var description strings.Builder
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		ops = append(ops, i.newOperation(cur, description.String()))
+		cur = nil
+		description.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, ":61:"):
+			flush()
+			s, err := parse61(line)
+			if err != nil {
+				return nil, err
+			}
+			cur = s
+		case strings.HasPrefix(line, ":86:"):
+			description.WriteString(strings.TrimPrefix(line, ":86:"))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return ops, nil
+}
+
+// parse61 extracts the value date, debit/credit mark and amount from a
+// SWIFT :61: line, e.g. ":61:2401150115DR1234,56NMSCNONREF".
+func parse61(line string) (*statement61, error) {
+	body := strings.TrimPrefix(line, ":61:")
+	if len(body) < 10 {
+		return nil, fmt.Errorf("mt940: malformed :61: line %q", line)
+	}
+
+	valueDate := body[:6]
+	rest := body[6:]
+
+	// Skip an optional 4-digit entry date (MMDD).
+	i := 0
+	for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+		i++
+	}
+	if i >= 4 {
+		rest = rest[4:]
+	}
+
+	if rest == "" {
+		return nil, fmt.Errorf("mt940: malformed :61: line %q", line)
+	}
+
+	sign := "D"
+	if rest[0] == 'C' {
+		sign = "C"
+	}
+	rest = strings.TrimLeft(rest, "CDRN")
+
+	// Amount runs until the next non-digit/non-comma character (the
+	// transaction type identification code).
+	j := 0
+	for j < len(rest) && (rest[j] == ',' || (rest[j] >= '0' && rest[j] <= '9')) {
+		j++
+	}
+
+	return &statement61{
+		valueDate: valueDate,
+		sign:      sign,
+		amount:    rest[:j],
+	}, nil
+}
+
+func (i *Importer) newOperation(s *statement61, description string) loan.Operation {
+	date, err := time.Parse("060102", s.valueDate)
+	iso := s.valueDate
+	if err == nil {
+		iso = date.Format("2006-01-02")
+	}
+
+	op := loan.Operation{
+		OrderDate: iso,
+		ExecDate:  iso,
+		Type:      "Spłata kredytu",
+		Amount:    loan.Amount{Currency: "PLN", Value: s.amount},
+	}
+	i.fillFromDescription(&op, description)
+	return op
+}
+
+// importCAMT053 parses the Ntry entries of an ISO 20022 CAMT.053 statement
+// into loan.Operations. NtryDtls is not read.
+func (i *Importer) importCAMT053(data []byte) ([]loan.Operation, error) {
+	var doc camt053Document
+	if err := unmarshalCAMT053(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var ops []loan.Operation
+	for _, stmt := range doc.BkToCstmrStmt.Stmt {
+		for _, entry := range stmt.Ntry {
+			op := loan.Operation{
+				OrderDate: entry.BookgDt.Dt,
+				ExecDate:  entry.ValDt.Dt,
+				Type:      "Spłata kredytu",
+				Amount:    loan.Amount{Currency: entry.Amt.Ccy, Value: entry.Amt.Value},
+			}
+			i.fillFromDescription(&op, entry.AddtlNtryInf)
+			ops = append(ops, op)
+		}
+	}
+
+	return ops, nil
+}
+
+// fillFromDescription runs the Importer's parser against a free-text
+// description and fills in the Kapitał/Odsetki breakdown and loan ID if it
+// matches.
+func (i *Importer) fillFromDescription(op *loan.Operation, description string) {
+	kapital, odsetki, odsetkiSkarpit, odsetkiKarne, id, ok := i.parser(description)
+	if !ok {
+		return
+	}
+
+	op.Kapital = kapital
+	op.Odsetki = odsetki
+	op.OdsetkiSkarpit = odsetkiSkarpit
+	op.OdsetkiKarne = odsetkiKarne
+	op.ID = id
+}
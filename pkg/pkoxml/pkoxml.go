@@ -0,0 +1,85 @@
+// Package pkoxml parses the XML account history export produced by PKO
+// Bank Polski's online banking "operations.xml" download into normalized
+// loan.Operation records.
+package pkoxml
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+// RawOperation mirrors a single <operation> node in the PKO export.
+type RawOperation struct {
+	OrderDate     string    `xml:"order-date"`
+	ExecDate      string    `xml:"exec-date"`
+	Type          string    `xml:"type"`
+	Description   string    `xml:"description"`
+	Amount        rawAmount `xml:"amount"`
+	EndingBalance rawAmount `xml:"ending-balance"`
+}
+
+type rawAmount struct {
+	Currency string `xml:"curr,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// AccountHistory mirrors the PKO export's <account-history> root element.
+type AccountHistory struct {
+	XMLName    xml.Name       `xml:"account-history"`
+	Operations []RawOperation `xml:"operations>operation"`
+}
+
+var loanDescriptionRe = regexp.MustCompile(`KAPITAŁ: ([0-9,]+)\s+ODSETKI: ([0-9,]+)\s+ODSETKI SKAPIT\.: ([0-9,]+)(?:\s+ODSETKI KARNE: ([0-9,]+))?\s+(\d+)`)
+
+// Importer reads a PKO "operations.xml" export.
+type Importer struct{}
+
+// New returns a PKO XML Importer.
+func New() *Importer {
+	return &Importer{}
+}
+
+// Import unmarshals a PKO account-history export and extracts every
+// "Spłata kredytu" operation as a loan.Operation, keyed by the loan ID
+// embedded in its description.
+func (Importer) Import(data []byte) ([]loan.Operation, error) {
+	var history AccountHistory
+	if err := xml.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+
+	var ops []loan.Operation
+	for _, op := range history.Operations {
+		if strings.TrimSpace(op.Type) != "Spłata kredytu" {
+			continue
+		}
+
+		m := loanDescriptionRe.FindStringSubmatch(op.Description)
+		if m == nil {
+			continue
+		}
+
+		odsetkiKarne := "0,00"
+		if len(m) >= 5 && m[4] != "" {
+			odsetkiKarne = m[4]
+		}
+
+		ops = append(ops, loan.Operation{
+			OrderDate:      op.OrderDate,
+			ExecDate:       op.ExecDate,
+			Type:           op.Type,
+			Amount:         loan.Amount{Currency: op.Amount.Currency, Value: op.Amount.Value},
+			EndingBalance:  loan.Amount{Currency: op.EndingBalance.Currency, Value: op.EndingBalance.Value},
+			Kapital:        m[1],
+			Odsetki:        m[2],
+			OdsetkiSkarpit: m[3],
+			OdsetkiKarne:   odsetkiKarne,
+			ID:             m[5],
+		})
+	}
+
+	return ops, nil
+}
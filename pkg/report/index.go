@@ -0,0 +1,358 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/event"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/shopspring/decimal"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+const indexFileName = "index.html"
+
+// loanMonth is one loan ID's aggregated activity in a single calendar
+// month.
+type loanMonth struct {
+	Kapital      decimal.Decimal
+	Odsetki      decimal.Decimal
+	OdsetkiKarne decimal.Decimal
+	Balance      decimal.Decimal
+	HasBalance   bool
+}
+
+// GenerateIndex writes "index.html", a dashboard aggregating every loan ID
+// found in ops: a multi-series chart of principal remaining per loan, a
+// stacked bar of monthly total household debt service, and per-year
+// summary cards.
+func GenerateIndex(ops []loan.Operation) error {
+	byID := GroupByID(ops)
+	if len(byID) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(byID))
+	perLoanMonths := make(map[string]map[string]*loanMonth, len(byID))
+	monthSet := make(map[string]struct{})
+
+	for id, ops := range byID {
+		ids = append(ids, id)
+		months := make(map[string]*loanMonth)
+		perLoanMonths[id] = months
+
+		for _, op := range ops {
+			dt, err := time.Parse("2006-01-02", op.OrderDate)
+			if err != nil {
+				continue
+			}
+			mon := monthKey(dt)
+			monthSet[mon] = struct{}{}
+
+			m, ok := months[mon]
+			if !ok {
+				m = &loanMonth{}
+				months[mon] = m
+			}
+			m.Kapital = m.Kapital.Add(loan.Amount{Value: op.Kapital}.Decimal())
+			m.Odsetki = m.Odsetki.Add(loan.Amount{Value: op.Odsetki}.Decimal())
+			m.OdsetkiKarne = m.OdsetkiKarne.Add(loan.Amount{Value: op.OdsetkiKarne}.Decimal())
+			m.Balance = op.EndingBalance.Decimal()
+			m.HasBalance = true
+		}
+	}
+	sort.Strings(ids)
+
+	months := make([]string, 0, len(monthSet))
+	for mon := range monthSet {
+		months = append(months, mon)
+	}
+	sort.Strings(months)
+	if len(months) == 0 {
+		return fmt.Errorf("report: no operation had a parseable order date")
+	}
+
+	balanceSeries := make(map[string][]opts.LineData, len(ids))
+	kapitalSeries := make(map[string][]opts.BarData, len(ids))
+	odsetkiSeries := make(map[string][]opts.BarData, len(ids))
+
+	monthKapital := make([]decimal.Decimal, len(months))
+	monthOdsetki := make([]decimal.Decimal, len(months))
+	monthKarne := make([]decimal.Decimal, len(months))
+	monthBalance := make([]decimal.Decimal, len(months))
+
+	for _, id := range ids {
+		var lastBalance decimal.Decimal
+		var started bool
+
+		line := make([]opts.LineData, len(months))
+		kapital := make([]opts.BarData, len(months))
+		odsetki := make([]opts.BarData, len(months))
+
+		for i, mon := range months {
+			m, ok := perLoanMonths[id][mon]
+			if ok && m.HasBalance {
+				lastBalance = m.Balance
+				started = true
+			}
+
+			if started {
+				line[i] = opts.LineData{Value: lastBalance.StringFixed(2)}
+				monthBalance[i] = monthBalance[i].Add(lastBalance)
+			}
+
+			if ok {
+				kapital[i] = opts.BarData{Value: m.Kapital.StringFixed(2)}
+				odsetki[i] = opts.BarData{Value: m.Odsetki.StringFixed(2)}
+				monthKapital[i] = monthKapital[i].Add(m.Kapital)
+				monthOdsetki[i] = monthOdsetki[i].Add(m.Odsetki)
+				monthKarne[i] = monthKarne[i].Add(m.OdsetkiKarne)
+			} else {
+				kapital[i] = opts.BarData{Value: "0.00"}
+				odsetki[i] = opts.BarData{Value: "0.00"}
+			}
+		}
+
+		balanceSeries[id] = line
+		kapitalSeries[id] = kapital
+		odsetkiSeries[id] = odsetki
+	}
+
+	years := yearCards(months, monthKapital, monthOdsetki, monthKarne, monthBalance)
+
+	line := buildBalanceChart(months, ids, balanceSeries)
+	bar := buildDebtServiceChart(months, ids, kapitalSeries, odsetkiSeries, years)
+
+	page := components.NewPage()
+	page.AddCharts(bar, line)
+
+	f, err := os.Create(indexFileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return page.Render(f)
+}
+
+func buildBalanceChart(months, ids []string, series map[string][]opts.LineData) *charts.Line {
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Saldo zadłużenia wg kredytu\n"}),
+		charts.WithXAxisOpts(opts.XAxis{Name: "Miesiąc"}),
+		charts.WithYAxisOpts(opts.YAxis{Name: "Saldo (PLN)"}),
+		charts.WithLegendOpts(opts.Legend{Show: opts.Bool(true), Top: "8%"}),
+		charts.WithDataZoomOpts(
+			opts.DataZoom{Type: "inside", XAxisIndex: []int{0}},
+			opts.DataZoom{Type: "slider", XAxisIndex: []int{0}},
+		),
+	)
+
+	line.SetXAxis(months)
+	for _, id := range ids {
+		line.AddSeries(fmt.Sprintf("ID %s", id), series[id])
+	}
+	return line
+}
+
+// buildDebtServiceChart renders the stacked monthly debt-service bar: every
+// loan contributes a Kapitał segment followed by an Odsetki segment, all
+// stacked into the same bar, so the total bar height is unchanged from a
+// single combined series per loan while the dataZoom handler can still sum
+// Kapitał and Odsetki separately — series are added in that fixed
+// Kapitał/Odsetki pair order, which the handler relies on via index parity.
+func buildDebtServiceChart(months, ids []string, kapital, odsetki map[string][]opts.BarData, years []yearCard) *charts.Bar {
+	bar := charts.NewBar()
+
+	bar.AddJSFuncStrs(
+		opts.FuncOpts(debtServiceWidgetsScript()),
+		opts.FuncOpts(yearCardsScript(years)),
+	)
+
+	bar.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "Łączna obsługa zadłużenia gospodarstwa domowego\n"}),
+		charts.WithXAxisOpts(opts.XAxis{Name: "Miesiąc"}),
+		charts.WithYAxisOpts(opts.YAxis{Name: "Kwota (PLN)"}),
+		charts.WithLegendOpts(opts.Legend{Show: opts.Bool(true), Top: "15%"}),
+		charts.WithDataZoomOpts(
+			opts.DataZoom{Type: "inside", XAxisIndex: []int{0}},
+			opts.DataZoom{Type: "slider", XAxisIndex: []int{0}},
+		),
+		charts.WithEventListeners(
+			event.Listener{
+				EventName: "dataZoom",
+				Handler: opts.FuncOpts(`
+		  function(params){
+        const option = this.getOption();
+        const series = option.series;
+        const dataZooms = option.dataZoom;
+        const startValue = dataZooms[0].startValue;
+        const endValue = dataZooms[0].endValue;
+
+		var sum_kapital = 0;
+		var sum_odsetki = 0;
+		for (var s = 0; s < series.length; s++) {
+			for (var i = startValue; i <= endValue; i++) {
+				var v = parseFloat(series[s].data[i].value);
+				if (s % 2 === 0) {
+					sum_kapital = v + sum_kapital;
+				} else {
+					sum_odsetki = v + sum_odsetki;
+				}
+			}
+		}
+		const kapital_e = document.getElementById('total_kapital_id');
+		if (kapital_e) {
+			kapital_e.textContent = "Suma kapitału wszystkich kredytów " + to2places(sum_kapital);
+		}
+		const odsetki_e = document.getElementById('total_odsetki_id');
+		if (odsetki_e) {
+			odsetki_e.textContent = "Suma odsetek wszystkich kredytów " + to2places(sum_odsetki);
+		}
+
+		updateYearCards(startValue, endValue);
+		}`),
+			},
+		),
+	)
+
+	bar.SetXAxis(months)
+	for _, id := range ids {
+		bar.AddSeries(fmt.Sprintf("ID %s Kapitał", id), kapital[id], charts.WithBarChartOpts(opts.BarChart{Stack: "stack"}))
+		bar.AddSeries(fmt.Sprintf("ID %s Odsetki", id), odsetki[id], charts.WithBarChartOpts(opts.BarChart{Stack: "stack"}))
+	}
+	return bar
+}
+
+func debtServiceWidgetsScript() string {
+	return `
+ document.body.insertAdjacentHTML('beforeend', '<h1 id="total_kapital_id">Suma kapitału wszystkich kredytów: N/A</h1>');
+ document.body.insertAdjacentHTML('beforeend', '<h1 id="total_odsetki_id">Suma odsetek wszystkich kredytów: N/A</h1>');
+ var formatter = new Intl.NumberFormat("de-DE", {
+  style: "currency",
+  currency: "PLN"
+});
+function to2places(x) {
+return formatter.format(x)
+}
+`
+}
+
+// yearCard is a single calendar year's aggregated totals across every loan.
+type yearCard struct {
+	Year             string
+	FromMonth        int
+	ToMonth          int
+	GrossPrincipal   decimal.Decimal
+	GrossInterest    decimal.Decimal
+	PenaltyInterest  decimal.Decimal
+	NetBalanceChange decimal.Decimal
+}
+
+// yearCards buckets the monthly aggregates by calendar year. NetBalanceChange
+// is the total outstanding balance at the year's last observed month minus
+// the balance just before the year's first observed month.
+func yearCards(months []string, kapital, odsetki, karne, balance []decimal.Decimal) []yearCard {
+	var cards []yearCard
+	var cur *yearCard
+
+	for i, mon := range months {
+		year := mon[:4]
+		if cur == nil || cur.Year != year {
+			if cur != nil {
+				cards = append(cards, *cur)
+			}
+			cur = &yearCard{Year: year, FromMonth: i, ToMonth: i}
+		}
+
+		cur.ToMonth = i
+		cur.GrossPrincipal = cur.GrossPrincipal.Add(kapital[i])
+		cur.GrossInterest = cur.GrossInterest.Add(odsetki[i])
+		cur.PenaltyInterest = cur.PenaltyInterest.Add(karne[i])
+	}
+	if cur != nil {
+		cards = append(cards, *cur)
+	}
+
+	for i := range cards {
+		startBalance := decimal.Zero
+		if cards[i].FromMonth > 0 {
+			startBalance = balance[cards[i].FromMonth-1]
+		}
+		cards[i].NetBalanceChange = balance[cards[i].ToMonth].Sub(startBalance)
+	}
+
+	return cards
+}
+
+// yearCardsScript embeds the precomputed year cards, each with the first and
+// last month index it spans, into the page, then defines
+// updateYearCards(startValue, endValue), which the dataZoom handler calls to
+// show only the cards of years overlapping the visible range. A shown card
+// always holds its whole year's totals; a partly visible year is not
+// re-summed over just its visible months.
+func yearCardsScript(years []yearCard) string {
+	type jsYearCard struct {
+		Year            string `json:"year"`
+		From            int    `json:"from"`
+		To              int    `json:"to"`
+		GrossPrincipal  string `json:"grossPrincipal"`
+		GrossInterest   string `json:"grossInterest"`
+		PenaltyInterest string `json:"penaltyInterest"`
+		NetBalance      string `json:"netBalance"`
+	}
+
+	jsYears := make([]jsYearCard, len(years))
+	for i, y := range years {
+		jsYears[i] = jsYearCard{
+			Year:            y.Year,
+			From:            y.FromMonth,
+			To:              y.ToMonth,
+			GrossPrincipal:  y.GrossPrincipal.StringFixed(2),
+			GrossInterest:   y.GrossInterest.StringFixed(2),
+			PenaltyInterest: y.PenaltyInterest.StringFixed(2),
+			NetBalance:      y.NetBalanceChange.StringFixed(2),
+		}
+	}
+
+	payload, _ := json.Marshal(jsYears)
+
+	return fmt.Sprintf(`
+document.body.insertAdjacentHTML('beforeend', '<div id="year_cards"></div>');
+
+var ALL_YEAR_CARDS = %s;
+
+function renderYearCard(y) {
+	return '<div class="year_card">' +
+		'<h3>' + y.year + '</h3>' +
+		'<p>Kapitał spłacony: ' + to2places(parseFloat(y.grossPrincipal)) + '</p>' +
+		'<p>Odsetki zapłacone: ' + to2places(parseFloat(y.grossInterest)) + '</p>' +
+		'<p>Odsetki karne: ' + to2places(parseFloat(y.penaltyInterest)) + '</p>' +
+		'<p>Zmiana salda: ' + to2places(parseFloat(y.netBalance)) + '</p>' +
+		'</div>';
+}
+
+function updateYearCards(startValue, endValue) {
+	var html = '';
+	for (var i = 0; i < ALL_YEAR_CARDS.length; i++) {
+		var y = ALL_YEAR_CARDS[i];
+		if (y.to < startValue || y.from > endValue) {
+			continue;
+		}
+		html += renderYearCard(y);
+	}
+	var el = document.getElementById('year_cards');
+	if (el) {
+		el.innerHTML = html;
+	}
+}
+
+updateYearCards(0, ALL_YEAR_CARDS.length > 0 ? ALL_YEAR_CARDS[ALL_YEAR_CARDS.length - 1].to : 0);
+`, string(payload))
+}
@@ -0,0 +1,404 @@
+// Package report turns a normalized loan.Operation stream into per-loan
+// ECharts HTML pages showing the monthly capital/interest split.
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/event"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/shopspring/decimal"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/amortize"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/cpi"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/xirr"
+)
+
+// Options configures optional Generate behavior.
+type Options struct {
+	// CPI and CPIBaseMonth ("2006-01") enable the "real PLN" view: every
+	// historical payment is deflated to CPIBaseMonth using CPI, and the
+	// generated bar charts gain a nominal/real toggle. Leave CPI nil to
+	// skip it.
+	CPI          cpi.Table
+	CPIBaseMonth string
+}
+
+// GroupByID buckets a flat Operation stream by loan ID, discarding
+// operations that don't carry one.
+func GroupByID(ops []loan.Operation) map[string][]loan.Operation {
+	byID := make(map[string][]loan.Operation)
+	for _, op := range ops {
+		if op.ID == "" {
+			continue
+		}
+		byID[op.ID] = append(byID[op.ID], op)
+	}
+	return byID
+}
+
+func sanitizeFileName(s string) string {
+	s = strings.ReplaceAll(s, " ", "_")
+	s = strings.ReplaceAll(s, "/", "_")
+	s = strings.ReplaceAll(s, "\\", "_")
+	return s
+}
+
+func monthKey(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// Generate writes, for every loan ID found in ops, a "splaty_bar_<id>.html"
+// history chart with an embedded summary card and a
+// "harmonogram_<id>.html" page comparing the reconstructed amortization
+// schedule against the actual payments. It reports progress and per-file
+// errors to stdout, matching the original tool's behavior.
+func Generate(ops []loan.Operation) {
+	GenerateWithOptions(ops, Options{})
+}
+
+// GenerateWithOptions is Generate with the "real PLN" view enabled when
+// opts.CPI is set.
+func GenerateWithOptions(ops []loan.Operation, opts Options) {
+	for id, ops := range GroupByID(ops) {
+		if len(ops) == 0 {
+			continue
+		}
+
+		schedule, err := amortize.Build(ops)
+		if err != nil {
+			fmt.Println("Błąd rekonstrukcji harmonogramu dla ID", id, ":", err)
+			continue
+		}
+		summary := amortize.Summarize(schedule)
+
+		var effectiveAnnualCost *float64
+		if flows, err := xirr.FromOperations(ops); err != nil {
+			fmt.Println("Błąd budowy przepływów XIRR dla ID", id, ":", err)
+		} else if rate, err := xirr.Calculate(flows); err != nil {
+			fmt.Println("Błąd obliczenia XIRR dla ID", id, ":", err)
+		} else {
+			effectiveAnnualCost = &rate
+		}
+
+		if err := generateBarChart(id, ops, summary, effectiveAnnualCost, opts); err != nil {
+			fmt.Println("Błąd renderowania bar chart dla ID", id, ":", err)
+			continue
+		}
+		if err := generateScheduleChart(id, schedule); err != nil {
+			fmt.Println("Błąd renderowania harmonogramu dla ID", id, ":", err)
+			continue
+		}
+
+		fmt.Println("Wygenerowano pliki dla ID:", id, "->", barFileName(id), scheduleFileName(id))
+	}
+
+	if err := GenerateIndex(ops); err != nil {
+		fmt.Println("Błąd renderowania strony zbiorczej:", err)
+	} else {
+		fmt.Println("Wygenerowano plik zbiorczy ->", indexFileName)
+	}
+}
+
+func barFileName(id string) string {
+	return fmt.Sprintf("splaty_bar_%s.html", sanitizeFileName(id))
+}
+
+func scheduleFileName(id string) string {
+	return fmt.Sprintf("harmonogram_%s.html", sanitizeFileName(id))
+}
+
+func generateBarChart(id string, ops []loan.Operation, summary amortize.Summary, effectiveAnnualCost *float64, cfg Options) error {
+	sort.Slice(ops, func(i, j int) bool {
+		ti, _ := time.Parse("2006-01-02", ops[i].OrderDate)
+		tj, _ := time.Parse("2006-01-02", ops[j].OrderDate)
+		return ti.Before(tj)
+	})
+
+	firstDate, _ := time.Parse("2006-01-02", ops[0].OrderDate)
+	lastDate, _ := time.Parse("2006-01-02", ops[len(ops)-1].OrderDate)
+
+	var months []string
+	cur := time.Date(firstDate.Year(), firstDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(lastDate.Year(), lastDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for !cur.After(end) {
+		months = append(months, monthKey(cur))
+		cur = cur.AddDate(0, 1, 0)
+	}
+
+	kapitalByMonth := make(map[string]decimal.Decimal)
+	odsetkiByMonth := make(map[string]decimal.Decimal)
+
+	for _, op := range ops {
+		dt, err := time.Parse("2006-01-02", op.OrderDate)
+		if err != nil {
+			continue
+		}
+		mon := monthKey(dt)
+		kapitalByMonth[mon] = kapitalByMonth[mon].Add(loan.Amount{Value: op.Kapital}.Decimal())
+		odsetkiByMonth[mon] = odsetkiByMonth[mon].Add(loan.Amount{Value: op.Odsetki}.Decimal())
+	}
+
+	var kapitalData []opts.BarData
+	var odsetkiData []opts.BarData
+
+	for _, mon := range months {
+		kapitalData = append(kapitalData, opts.BarData{Value: kapitalByMonth[mon].StringFixed(2)})
+		odsetkiData = append(odsetkiData, opts.BarData{Value: odsetkiByMonth[mon].StringFixed(2)})
+	}
+
+	var discountData []opts.BarData
+	var realScript string
+	if cfg.CPI != nil {
+		cfg.CPI.ForwardFill(months)
+
+		kapitalReal := make([]string, len(months))
+		odsetkiReal := make([]string, len(months))
+		cumDiscount := decimal.Zero
+
+		for i, mon := range months {
+			realKapital, err := cfg.CPI.Deflate(kapitalByMonth[mon], mon, cfg.CPIBaseMonth)
+			if err != nil {
+				return fmt.Errorf("report: real PLN view for ID %s: %w", id, err)
+			}
+			realOdsetki, err := cfg.CPI.Deflate(odsetkiByMonth[mon], mon, cfg.CPIBaseMonth)
+			if err != nil {
+				return fmt.Errorf("report: real PLN view for ID %s: %w", id, err)
+			}
+
+			kapitalReal[i] = realKapital.StringFixed(2)
+			odsetkiReal[i] = realOdsetki.StringFixed(2)
+
+			cumDiscount = cumDiscount.Add(kapitalByMonth[mon].Sub(realKapital))
+			discountData = append(discountData, opts.BarData{Value: cumDiscount.StringFixed(2)})
+		}
+
+		nominalKapital := make([]string, len(months))
+		nominalOdsetki := make([]string, len(months))
+		for i, mon := range months {
+			nominalKapital[i] = kapitalByMonth[mon].StringFixed(2)
+			nominalOdsetki[i] = odsetkiByMonth[mon].StringFixed(2)
+		}
+
+		realScript = realModeScript(cfg.CPIBaseMonth, nominalKapital, nominalOdsetki, kapitalReal, odsetkiReal)
+	}
+
+	barTitle := fmt.Sprintf("Spłaty — ID %s\n", id)
+	bar := charts.NewBar()
+	actionWithEchartsInstance := `
+ document.body.insertAdjacentHTML('beforeend', '<h1 id="kapital_id">Kapital: N/A</h1>');
+ document.body.insertAdjacentHTML('beforeend', '<h1 id="odsetki_id">Odsetki: N/A</h1>');
+ var formatter = new Intl.NumberFormat("de-DE", {
+  style: "currency",
+  currency: "PLN"
+});
+function to2places(x) {
+
+return formatter.format(x)
+
+}
+
+`
+
+	bar.AddJSFuncStrs(opts.FuncOpts(actionWithEchartsInstance), opts.FuncOpts(summaryCardScript(summary, effectiveAnnualCost)))
+	if realScript != "" {
+		bar.AddJSFuncStrs(opts.FuncOpts(realScript))
+	}
+
+	bar.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: barTitle}),
+		charts.WithXAxisOpts(opts.XAxis{Name: "Miesiąc"}),
+		charts.WithYAxisOpts(opts.YAxis{Name: "Kwota (PLN)"}),
+		charts.WithLegendOpts(opts.Legend{Show: opts.Bool(true), Top: "15%"}),
+		charts.WithDataZoomOpts(
+			opts.DataZoom{
+				Type:       "inside",
+				XAxisIndex: []int{0},
+			},
+			opts.DataZoom{
+				Type:       "slider",
+				XAxisIndex: []int{0},
+			},
+		),
+		charts.WithEventListeners(
+			event.Listener{
+				EventName: "dataZoom",
+				Handler: opts.FuncOpts(`
+		  function(params){
+        const option = this.getOption();
+        const series = option.series;
+        const dataZooms = option.dataZoom;
+        const startValue = dataZooms[0].startValue;
+        const endValue = dataZooms[0].endValue;
+
+		var sum_kapital = 0;
+		var sum_odsetki = 0;
+		for (var i = startValue;i<=endValue;i++)
+		{
+				sum_kapital = parseFloat(series[0].data[i].value) + sum_kapital;
+				sum_odsetki = parseFloat(series[1].data[i].value) + sum_odsetki;
+
+		}
+				const kapital_e = document.getElementById('kapital_id');
+                        if (kapital_e) {
+                            kapital_e.textContent = "Suma kapitalu " + to2places(sum_kapital);
+                        }
+						const odsetki_e = document.getElementById('odsetki_id');
+                        if (odsetki_e) {
+                            odsetki_e.textContent = "Suma odsetek " + to2places(sum_odsetki);
+                        }
+
+		}`),
+			},
+		),
+	)
+
+	bar.SetXAxis(months).
+		AddSeries("Kapitał", kapitalData, charts.WithBarChartOpts(opts.BarChart{Stack: "stack"})).
+		AddSeries("Odsetki", odsetkiData, charts.WithBarChartOpts(opts.BarChart{Stack: "stack"}))
+
+	if discountData != nil {
+		bar.AddSeries("Dyskonto inflacyjne (skumulowane)", discountData)
+	}
+
+	f, err := os.Create(barFileName(id))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return bar.Render(f)
+}
+
+// summaryCardScript renders a loan's Summary as a static HTML card. Unlike
+// the Kapitał/Odsetki widgets above, these totals don't depend on the
+// dataZoom range, so they're inserted once rather than recomputed on the
+// chart.
+func summaryCardScript(s amortize.Summary, effectiveAnnualCost *float64) string {
+	effectivePct := s.EffectiveRate.Mul(decimal.NewFromInt(100))
+
+	xirrPct := "N/D"
+	if effectiveAnnualCost != nil {
+		xirrPct = fmt.Sprintf("%.2f", *effectiveAnnualCost*100)
+	}
+
+	return fmt.Sprintf(`
+document.body.insertAdjacentHTML('beforeend',
+ '<div id="summary_card">' +
+ '<h2>Podsumowanie</h2>' +
+ '<p>Łącznie spłacono: %s PLN</p>' +
+ '<p>Łącznie odsetek: %s PLN</p>' +
+ '<p>Pozostało do spłaty: %s PLN</p>' +
+ '<p>Efektywne oprocentowanie: %s%%</p>' +
+ '<p>Rzeczywisty koszt kredytu (XIRR): %s%%</p>' +
+ '<p>Przewidywana data spłaty: %s</p>' +
+ '</div>');
+`,
+		s.TotalPaid.StringFixed(2),
+		s.TotalInterest.StringFixed(2),
+		s.RemainingBalance.StringFixed(2),
+		effectivePct.StringFixed(2),
+		xirrPct,
+		s.PayoffDate,
+	)
+}
+
+// realModeScript renders a nominal/real toggle for the bar chart. Flipping
+// it swaps the Kapitał/Odsetki series' data between the precomputed
+// nominal and CPI-deflated arrays, so the dataZoom handler's "Suma
+// kapitalu/odsetek" widgets — which always read off the chart's current
+// series data — pick up whichever mode is active without any extra logic.
+func realModeScript(baseMonth string, nominalKapital, nominalOdsetki, realKapital, realOdsetki []string) string {
+	toJSArray := func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = fmt.Sprintf("%q", v)
+		}
+		return "[" + strings.Join(quoted, ",") + "]"
+	}
+
+	header := fmt.Sprintf(`
+document.body.insertAdjacentHTML('beforeend', '<label><input type="checkbox" id="real_toggle"/> Pokaż wartości realne (PLN wg %s)</label>');
+
+var NOMINAL_KAPITAL = %s;
+var NOMINAL_ODSETKI = %s;
+var REAL_KAPITAL = %s;
+var REAL_ODSETKI = %s;
+`,
+		baseMonth,
+		toJSArray(nominalKapital), toJSArray(nominalOdsetki),
+		toJSArray(realKapital), toJSArray(realOdsetki),
+	)
+
+	// %MY_ECHARTS% is go-echarts' own injectInstance placeholder (see
+	// render/engine.go), substituted with the chart's real instance
+	// variable. It's appended as a plain literal, not through Sprintf, so
+	// it can't be mistaken for a format verb.
+	return header + `
+(function() {
+	var chart = %MY_ECHARTS%;
+	var toggle = document.getElementById('real_toggle');
+	if (!toggle) { return; }
+	toggle.addEventListener('change', function() {
+		var opt = chart.getOption();
+		var kapital = toggle.checked ? REAL_KAPITAL : NOMINAL_KAPITAL;
+		var odsetki = toggle.checked ? REAL_ODSETKI : NOMINAL_ODSETKI;
+		opt.series[0].data = kapital.map(function(v) { return {value: v}; });
+		opt.series[1].data = odsetki.map(function(v) { return {value: v}; });
+		chart.setOption(opt, true);
+	});
+})();
+`
+}
+
+// generateScheduleChart writes "harmonogram_<id>.html", a line chart
+// comparing the actual outstanding balance against the projected balance
+// amortize.Build extrapolated to payoff.
+func generateScheduleChart(id string, schedule *amortize.Schedule) error {
+	var months []string
+	var actualBalance []opts.LineData
+	var projectedBalance []opts.LineData
+
+	for _, p := range schedule.Actual {
+		months = append(months, p.Month)
+		actualBalance = append(actualBalance, opts.LineData{Value: p.Balance.StringFixed(2)})
+		projectedBalance = append(projectedBalance, opts.LineData{})
+	}
+	for i, p := range schedule.Projected {
+		months = append(months, p.Month)
+		actualBalance = append(actualBalance, opts.LineData{})
+		if i == 0 {
+			// Bridge the two series so the line doesn't visually break
+			// between the last actual month and the first projected one.
+			projectedBalance[len(projectedBalance)-1] = actualBalance[len(actualBalance)-2]
+		}
+		projectedBalance = append(projectedBalance, opts.LineData{Value: p.Balance.StringFixed(2)})
+	}
+
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: fmt.Sprintf("Harmonogram spłat — ID %s\n", id)}),
+		charts.WithXAxisOpts(opts.XAxis{Name: "Miesiąc"}),
+		charts.WithYAxisOpts(opts.YAxis{Name: "Saldo (PLN)"}),
+		charts.WithLegendOpts(opts.Legend{Show: opts.Bool(true), Top: "15%"}),
+	)
+
+	line.SetXAxis(months).
+		AddSeries("Saldo rzeczywiste", actualBalance).
+		AddSeries("Saldo prognozowane", projectedBalance)
+
+	f, err := os.Create(scheduleFileName(id))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return line.Render(f)
+}
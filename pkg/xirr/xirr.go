@@ -0,0 +1,179 @@
+// Package xirr computes the effective annual rate of a dated, irregular
+// cashflow series via Newton-Raphson on the NPV function, the same measure
+// spreadsheet XIRR() functions report.
+package xirr
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+const (
+	newtonStart         = 0.1
+	maxNewtonIterations = 50
+	newtonTolerance     = 1e-7
+
+	bisectionLow           = -0.999
+	bisectionHigh          = 10.0
+	maxBisectionIterations = 100
+)
+
+// CashFlow is a single dated cash movement.
+type CashFlow struct {
+	Date   time.Time
+	Amount float64
+}
+
+// FromOperations builds a loan's cashflow series: the inferred initial
+// disbursement (the first observed EndingBalance plus the principal of
+// every repayment, the first one included) as an inflow on the first
+// operation's date,
+// followed by each "Spłata kredytu" payment as an outflow on its order
+// date.
+func FromOperations(ops []loan.Operation) ([]CashFlow, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("xirr: no operations to build a cashflow series from")
+	}
+
+	sorted := make([]loan.Operation, len(ops))
+	copy(sorted, ops)
+	sort.Slice(sorted, func(i, j int) bool {
+		ti, _ := time.Parse("2006-01-02", sorted[i].OrderDate)
+		tj, _ := time.Parse("2006-01-02", sorted[j].OrderDate)
+		return ti.Before(tj)
+	})
+
+	firstDate, err := time.Parse("2006-01-02", sorted[0].OrderDate)
+	if err != nil {
+		return nil, fmt.Errorf("xirr: invalid order date %q: %w", sorted[0].OrderDate, err)
+	}
+
+	disbursement := sorted[0].EndingBalance.Decimal()
+	for _, op := range sorted {
+		disbursement = disbursement.Add(loan.Amount{Value: op.Kapital}.Decimal())
+	}
+	disbursementF, _ := disbursement.Float64()
+
+	flows := []CashFlow{{Date: firstDate, Amount: disbursementF}}
+
+	for _, op := range sorted {
+		dt, err := time.Parse("2006-01-02", op.OrderDate)
+		if err != nil {
+			continue
+		}
+		payment := loan.Amount{Value: op.Kapital}.Decimal().Add(loan.Amount{Value: op.Odsetki}.Decimal())
+		paymentF, _ := payment.Float64()
+		flows = append(flows, CashFlow{Date: dt, Amount: -paymentF})
+	}
+
+	return flows, nil
+}
+
+// Calculate solves for the annualized rate r that makes
+// sum(cf_i / (1+r)^((d_i-d_0)/365)) == 0, using Newton-Raphson from
+// newtonStart and falling back to bisection over
+// [bisectionLow, bisectionHigh] if Newton diverges or its derivative gets
+// too close to zero.
+func Calculate(flows []CashFlow) (float64, error) {
+	if len(flows) < 2 {
+		return 0, fmt.Errorf("xirr: need at least two cash flows")
+	}
+
+	hasInflow, hasOutflow := false, false
+	for _, cf := range flows {
+		if cf.Amount > 0 {
+			hasInflow = true
+		}
+		if cf.Amount < 0 {
+			hasOutflow = true
+		}
+	}
+	if !hasInflow || !hasOutflow {
+		return 0, fmt.Errorf("xirr: cash flows must contain both an inflow and an outflow")
+	}
+
+	npv, dnpv := npvFuncs(flows)
+
+	r := newtonStart
+	for i := 0; i < maxNewtonIterations; i++ {
+		f := npv(r)
+		if math.Abs(f) < newtonTolerance {
+			return r, nil
+		}
+
+		d := dnpv(r)
+		if math.Abs(d) < 1e-12 {
+			break
+		}
+
+		next := r - f/d
+		if math.IsNaN(next) || math.IsInf(next, 0) || next <= bisectionLow {
+			break
+		}
+		r = next
+	}
+
+	return bisect(npv)
+}
+
+func npvFuncs(flows []CashFlow) (npv, dnpv func(r float64) float64) {
+	t0 := flows[0].Date
+
+	years := func(cf CashFlow) float64 {
+		return cf.Date.Sub(t0).Hours() / 24 / 365
+	}
+
+	npv = func(r float64) float64 {
+		sum := 0.0
+		for _, cf := range flows {
+			sum += cf.Amount / math.Pow(1+r, years(cf))
+		}
+		return sum
+	}
+
+	dnpv = func(r float64) float64 {
+		sum := 0.0
+		for _, cf := range flows {
+			y := years(cf)
+			if y == 0 {
+				continue
+			}
+			sum += -y * cf.Amount / math.Pow(1+r, y+1)
+		}
+		return sum
+	}
+
+	return npv, dnpv
+}
+
+func bisect(npv func(r float64) float64) (float64, error) {
+	lo, hi := bisectionLow, bisectionHigh
+	fLo, fHi := npv(lo), npv(hi)
+
+	if math.IsNaN(fLo) || math.IsNaN(fHi) || sameSign(fLo, fHi) {
+		return 0, fmt.Errorf("xirr: could not bracket a root in [%.3f, %.3f]", bisectionLow, bisectionHigh)
+	}
+
+	for i := 0; i < maxBisectionIterations; i++ {
+		mid := (lo + hi) / 2
+		fMid := npv(mid)
+		if math.Abs(fMid) < newtonTolerance {
+			return mid, nil
+		}
+		if sameSign(fMid, fLo) {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2, nil
+}
+
+func sameSign(a, b float64) bool {
+	return (a > 0) == (b > 0)
+}
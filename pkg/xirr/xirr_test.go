@@ -0,0 +1,57 @@
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/MenosGrandes/PKO-XML-LoanParser/pkg/loan"
+)
+
+func TestCalculate_KnownRate(t *testing.T) {
+	flows := []CashFlow{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Amount: -100},
+		{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 110},
+	}
+
+	rate, err := Calculate(flows)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if math.Abs(rate-0.10) > 0.01 {
+		t.Errorf("rate = %v, want ~0.10", rate)
+	}
+}
+
+func TestCalculate_RequiresBothDirections(t *testing.T) {
+	flows := []CashFlow{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 100},
+		{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 50},
+	}
+	if _, err := Calculate(flows); err == nil {
+		t.Fatal("Calculate with only inflows: want error, got nil")
+	}
+}
+
+func TestFromOperations(t *testing.T) {
+	ops := []loan.Operation{
+		{OrderDate: "2024-01-15", Kapital: "100,00", Odsetki: "10,00", EndingBalance: loan.Amount{Value: "900,00"}},
+		{OrderDate: "2024-02-15", Kapital: "100,00", Odsetki: "9,00", EndingBalance: loan.Amount{Value: "800,00"}},
+	}
+
+	flows, err := FromOperations(ops)
+	if err != nil {
+		t.Fatalf("FromOperations: %v", err)
+	}
+	if len(flows) != 3 {
+		t.Fatalf("got %d flows, want 3 (disbursement + 2 payments)", len(flows))
+	}
+	if flows[0].Amount <= 0 {
+		t.Errorf("disbursement amount = %v, want positive", flows[0].Amount)
+	}
+	for _, cf := range flows[1:] {
+		if cf.Amount >= 0 {
+			t.Errorf("payment amount = %v, want negative", cf.Amount)
+		}
+	}
+}